@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command serving-lint validates Route manifests against the same
+// validation path the admission webhook runs, so a manifest can be checked
+// in CI before `kubectl apply` without the behavior drifting from what the
+// cluster will actually accept.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/knative/serving/pkg/apis/serving/v1alpha1/lint"
+)
+
+func main() {
+	format := flag.String("format", "text", "output format: text|json|sarif")
+	strict := flag.Bool("strict", false, "promote warn and dryrun findings to hard failures")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: serving-lint [--format text|json|sarif] [--strict] FILE [FILE...]")
+		os.Exit(2)
+	}
+
+	var all []lint.Finding
+	for _, path := range flag.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		findings, err := lint.Lint(path, f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		all = append(all, findings...)
+	}
+
+	if err := lint.Report(os.Stdout, *format, all); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// A deny-scoped finding fails the run regardless of --strict, the
+	// same as the admission webhook would reject it. --strict further
+	// promotes warn/dryrun findings, which the webhook would otherwise
+	// admit, to hard failures too.
+	for _, f := range all {
+		if f.Blocks() || *strict {
+			os.Exit(1)
+		}
+	}
+}
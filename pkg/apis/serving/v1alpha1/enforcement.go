@@ -0,0 +1,158 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnforcementAction determines what a validation failure does once it has
+// been found: whether it blocks admission, is surfaced as a warning, or is
+// merely recorded for later audit.
+type EnforcementAction string
+
+const (
+	// EnforceDeny blocks admission of the offending resource. This is the
+	// default when no annotation is present, preserving today's behavior.
+	EnforceDeny EnforcementAction = "deny"
+	// EnforceWarn admits the resource but surfaces the failure to the
+	// caller as an admission warning.
+	EnforceWarn EnforcementAction = "warn"
+	// EnforceDryRun admits the resource and does not surface anything to
+	// the caller; the failure is only recorded for auditing.
+	EnforceDryRun EnforcementAction = "dryrun"
+)
+
+// EnforcementScope determines which validation path an EnforcementAction
+// applies to.
+type EnforcementScope string
+
+const (
+	// ScopeWebhook applies the action to the synchronous admission webhook
+	// path (the default).
+	ScopeWebhook EnforcementScope = "webhook"
+	// ScopeAudit applies the action to an out-of-band background audit
+	// pass over already-admitted resources.
+	ScopeAudit EnforcementScope = "audit"
+)
+
+// EnforcementActionAnnotationKey is the annotation operators set on a
+// Route or Configuration to opt into non-default enforcement of its
+// validation failures.
+const EnforcementActionAnnotationKey = "serving.knative.dev/enforcementAction"
+
+// EnforcementPolicy pairs an EnforcementAction with the EnforcementScope it
+// applies to.
+type EnforcementPolicy struct {
+	Action EnforcementAction `json:"action"`
+	Scope  EnforcementScope  `json:"scope,omitempty"`
+}
+
+// defaultEnforcementPolicy is applied when a resource carries no
+// EnforcementActionAnnotationKey annotation, preserving the historical
+// hard-deny-on-webhook behavior of Validate().
+var defaultEnforcementPolicy = EnforcementPolicy{
+	Action: EnforceDeny,
+	Scope:  ScopeWebhook,
+}
+
+// ValidationResult pairs accumulated validation failures with the
+// EnforcementPolicy that should be applied to them for a given resource.
+type ValidationResult struct {
+	Errs   FieldErrors
+	Policy EnforcementPolicy
+}
+
+// EnforceValidation evaluates errs against the EnforcementPolicy declared by
+// obj's EnforcementActionAnnotationKey annotation (or the default policy if
+// unset), returning nil when errs is empty.
+func EnforceValidation(obj metav1.Object, errs FieldErrors) *ValidationResult {
+	if len(errs) == 0 {
+		return nil
+	}
+	policy, parseErr := enforcementPolicyFor(obj)
+	if parseErr != nil {
+		// An unparsable annotation falls back to the safe default rather
+		// than silently admitting the resource.
+		policy = defaultEnforcementPolicy
+	}
+	return &ValidationResult{Errs: errs, Policy: policy}
+}
+
+// enforcementPolicyFor extracts the EnforcementPolicy from obj's
+// annotations, accepting either a bare action ("warn") which defaults to
+// ScopeWebhook, or a JSON object (`{"action":"warn","scope":"audit"}`).
+func enforcementPolicyFor(obj metav1.Object) (EnforcementPolicy, error) {
+	raw, ok := obj.GetAnnotations()[EnforcementActionAnnotationKey]
+	if !ok || raw == "" {
+		return defaultEnforcementPolicy, nil
+	}
+
+	var policy EnforcementPolicy
+	if err := json.Unmarshal([]byte(raw), &policy); err == nil && policy.Action != "" {
+		if policy.Scope == "" {
+			policy.Scope = ScopeWebhook
+		}
+		switch policy.Action {
+		case EnforceDeny, EnforceWarn, EnforceDryRun:
+		default:
+			return EnforcementPolicy{}, fmt.Errorf("invalid action %q in %s annotation: %q", policy.Action, EnforcementActionAnnotationKey, raw)
+		}
+		switch policy.Scope {
+		case ScopeWebhook, ScopeAudit:
+		default:
+			return EnforcementPolicy{}, fmt.Errorf("invalid scope %q in %s annotation: %q", policy.Scope, EnforcementActionAnnotationKey, raw)
+		}
+		return policy, nil
+	}
+
+	switch EnforcementAction(raw) {
+	case EnforceDeny, EnforceWarn, EnforceDryRun:
+		return EnforcementPolicy{Action: EnforcementAction(raw), Scope: ScopeWebhook}, nil
+	default:
+		return EnforcementPolicy{}, fmt.Errorf("invalid %s annotation: %q", EnforcementActionAnnotationKey, raw)
+	}
+}
+
+// Blocks reports whether this result should block admission for the given
+// scope: only a Deny-scoped-to-scope result blocks.
+func (vr *ValidationResult) Blocks(scope EnforcementScope) bool {
+	return vr != nil && vr.Policy.Action == EnforceDeny && vr.Policy.Scope == scope
+}
+
+// Warnings returns the admission warning strings to surface for this
+// result, which is non-empty only for a Warn result scoped to the webhook.
+// Each accumulated FieldError becomes its own warning string.
+func (vr *ValidationResult) Warnings() []string {
+	if vr == nil || vr.Policy.Action != EnforceWarn || vr.Policy.Scope != ScopeWebhook {
+		return nil
+	}
+	warnings := make([]string, 0, len(vr.Errs))
+	for _, err := range vr.Errs {
+		warnings = append(warnings, err.Error())
+	}
+	return warnings
+}
+
+// IsDryRun reports whether this result should only be recorded (as an
+// event or metric) without blocking or warning the caller.
+func (vr *ValidationResult) IsDryRun() bool {
+	return vr != nil && vr.Policy.Action == EnforceDryRun
+}
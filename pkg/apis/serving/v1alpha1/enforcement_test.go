@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// badTrafficRoute is a Route whose traffic sums to 198, which Validate()
+// always rejects regardless of enforcement policy.
+func badTrafficRoute(annotations map[string]string) *Route {
+	return &Route{
+		ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+		Spec: RouteSpec{
+			Traffic: []TrafficTarget{{
+				RevisionName: "bar",
+				Percent:      99,
+			}, {
+				RevisionName: "baz",
+				Percent:      99,
+			}},
+		},
+	}
+}
+
+func TestEnforceValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantBlocks  bool
+		wantWarning bool
+		wantDryRun  bool
+	}{{
+		name:        "no annotation defaults to deny on webhook",
+		annotations: nil,
+		wantBlocks:  true,
+	}, {
+		name:        "bare warn annotation",
+		annotations: map[string]string{EnforcementActionAnnotationKey: "warn"},
+		wantWarning: true,
+	}, {
+		name:        "scoped deny on webhook",
+		annotations: map[string]string{EnforcementActionAnnotationKey: `{"action":"deny","scope":"webhook"}`},
+		wantBlocks:  true,
+	}, {
+		name:        "scoped deny on audit does not block webhook",
+		annotations: map[string]string{EnforcementActionAnnotationKey: `{"action":"deny","scope":"audit"}`},
+		wantBlocks:  false,
+	}, {
+		name:        "dryrun neither blocks nor warns",
+		annotations: map[string]string{EnforcementActionAnnotationKey: "dryrun"},
+		wantDryRun:  true,
+	}, {
+		name:        "unparsable annotation falls back to deny",
+		annotations: map[string]string{EnforcementActionAnnotationKey: "not-a-real-action"},
+		wantBlocks:  true,
+	}, {
+		name:        "scoped annotation with unrecognized action falls back to deny",
+		annotations: map[string]string{EnforcementActionAnnotationKey: `{"action":"YOLO"}`},
+		wantBlocks:  true,
+	}, {
+		name:        "scoped annotation with unrecognized scope falls back to deny",
+		annotations: map[string]string{EnforcementActionAnnotationKey: `{"action":"warn","scope":"YOLO"}`},
+		wantBlocks:  true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := badTrafficRoute(test.annotations)
+			errs := r.Spec.Validate()
+			if len(errs) == 0 {
+				t.Fatal("Validate() = empty, want an error from the bad traffic split")
+			}
+
+			result := EnforceValidation(r, errs)
+			if got := result.Blocks(ScopeWebhook); got != test.wantBlocks {
+				t.Errorf("Blocks(ScopeWebhook) = %v, want %v", got, test.wantBlocks)
+			}
+			if got := len(result.Warnings()) > 0; got != test.wantWarning {
+				t.Errorf("len(Warnings()) > 0 = %v, want %v", got, test.wantWarning)
+			}
+			if got := result.IsDryRun(); got != test.wantDryRun {
+				t.Errorf("IsDryRun() = %v, want %v", got, test.wantDryRun)
+			}
+		})
+	}
+}
+
+func TestEnforceValidationNilError(t *testing.T) {
+	r := &Route{Spec: RouteSpec{Traffic: []TrafficTarget{{RevisionName: "foo", Percent: 100}}}}
+	if got := EnforceValidation(r, r.Spec.Validate()); got != nil {
+		t.Errorf("EnforceValidation() = %+v, want nil", got)
+	}
+}
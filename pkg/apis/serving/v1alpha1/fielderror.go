@@ -0,0 +1,211 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// currentField is used to denote the current field in a FieldError's Paths
+// when the error does not apply to a sub-field.
+const currentField = ""
+
+// Well-known, stable error codes surfaced on FieldError.Code so that
+// tooling (kn, CI linters, the serving-lint binary) can branch on the kind
+// of failure without parsing Message.
+const (
+	CodeMissingField             = "MissingField"
+	CodeInvalidValue             = "InvalidValue"
+	CodeTrafficPercentOutOfRange = "TrafficPercentOutOfRange"
+	CodeTrafficTargetAmbiguous   = "TrafficTargetAmbiguous"
+	CodeDuplicateTrafficName     = "DuplicateTrafficName"
+	CodeDuplicateTrafficTag      = "DuplicateTrafficTag"
+)
+
+// FieldError is a single, machine-readable validation failure against a
+// particular field (or set of mutually-exclusive fields) of a resource.
+type FieldError struct {
+	// Message is a human-readable summary of the failure.
+	Message string
+	// Code is a stable, machine-readable identifier for the kind of
+	// failure, e.g. CodeTrafficPercentOutOfRange.
+	Code string
+	// Paths are the Go-struct-literal field paths this error applies to
+	// (e.g. "traffic[0].revisionName"), kept for existing field-level
+	// tooling that doesn't speak JSONPath.
+	Paths []string
+	// JSONPath is the JSONPath expression identifying the offending field
+	// in the serialized resource, e.g. "$.spec.traffic[0].revisionName".
+	// It is empty for errors that don't have a single obvious JSONPath
+	// (e.g. those spanning multiple Paths).
+	JSONPath string
+	// Suggestion is an optional, human-readable fix for the failure.
+	Suggestion string
+}
+
+// Error implements error.
+func (fe *FieldError) Error() string {
+	if fe == nil {
+		return ""
+	}
+	msg := fmt.Sprintf("%v: %v", fe.Message, strings.Join(fe.Paths, ", "))
+	if fe.Suggestion != "" {
+		msg = fmt.Sprintf("%s (suggestion: %s)", msg, fe.Suggestion)
+	}
+	return msg
+}
+
+// ViaField returns a copy of the receiver with the given prefix(es)
+// prepended to each of its Paths and to its JSONPath. This is used by
+// parents to decorate errors returned by a child's Validate() with the
+// field under which the child was found (e.g. "spec").
+func (fe *FieldError) ViaField(prefix ...string) *FieldError {
+	if fe == nil {
+		return nil
+	}
+	newPaths := make([]string, 0, len(fe.Paths))
+	for _, p := range fe.Paths {
+		joined := append(append([]string{}, prefix...), p)
+		newPaths = append(newPaths, strings.Join(joined, "."))
+	}
+
+	newJSONPath := fe.JSONPath
+	if newJSONPath != "" {
+		newJSONPath = "$." + strings.Join(prefix, ".") + "." + strings.TrimPrefix(newJSONPath, "$.")
+	}
+
+	return &FieldError{
+		Message:    fe.Message,
+		Code:       fe.Code,
+		Paths:      newPaths,
+		JSONPath:   newJSONPath,
+		Suggestion: fe.Suggestion,
+	}
+}
+
+// FieldErrors accumulates zero or more FieldError entries produced during a
+// single validation pass, instead of a Validate() method stopping at the
+// first problem it encounters.
+type FieldErrors []*FieldError
+
+// Error implements error, joining every accumulated entry's message.
+func (fes FieldErrors) Error() string {
+	msgs := make([]string, 0, len(fes))
+	for _, fe := range fes {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Also appends the given errors (ignoring any nils) to the receiver,
+// returning the accumulated list. It is the primary way Validate()
+// implementations build up FieldErrors across multiple independent checks.
+func (fes FieldErrors) Also(errs ...*FieldError) FieldErrors {
+	out := fes
+	for _, err := range errs {
+		if err != nil {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// ViaField returns a copy of fes with prefix prepended to every entry, for
+// use by a parent whose Validate() embeds a child's FieldErrors under a
+// named field (e.g. "spec").
+func (fes FieldErrors) ViaField(prefix ...string) FieldErrors {
+	if len(fes) == 0 {
+		return nil
+	}
+	out := make(FieldErrors, 0, len(fes))
+	for _, fe := range fes {
+		out = append(out, fe.ViaField(prefix...))
+	}
+	return out
+}
+
+// AsError returns fes as an error, or nil when fes is empty. This is the
+// typical final line of a Validate() method: `return errs.AsError()`.
+func (fes FieldErrors) AsError() error {
+	if len(fes) == 0 {
+		return nil
+	}
+	return fes
+}
+
+// ToAdmissionResponse converts fes into an AdmissionResponse suitable for
+// a validating webhook to return: allowed when fes is empty, denied with
+// every accumulated message otherwise.
+func (fes FieldErrors) ToAdmissionResponse() *admissionv1.AdmissionResponse {
+	if len(fes) == 0 {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Message: fes.Error(),
+			Reason:  metav1.StatusReasonInvalid,
+		},
+	}
+}
+
+// FieldErrorsCompareOption is passed to cmp.Diff so table-driven tests can
+// compare FieldErrors (slices of *FieldError) by value instead of tripping
+// over pointer identity.
+var FieldErrorsCompareOption = cmp.Comparer(func(a, b *FieldError) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Message != b.Message || a.Code != b.Code || a.JSONPath != b.JSONPath || a.Suggestion != b.Suggestion {
+		return false
+	}
+	if len(a.Paths) != len(b.Paths) {
+		return false
+	}
+	for i := range a.Paths {
+		if a.Paths[i] != b.Paths[i] {
+			return false
+		}
+	}
+	return true
+})
+
+// errMissingField is a variadic helper for constructing a FieldError for
+// the case in which a required field (or one-of a set of fields) is unset.
+func errMissingField(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "Missing field(s)",
+		Code:    CodeMissingField,
+		Paths:   fieldPaths,
+	}
+}
+
+// errInvalidValue constructs a FieldError for the case in which a field is
+// set to a value that is not permitted.
+func errInvalidValue(value, fieldPath string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("invalid value %q", value),
+		Code:     CodeInvalidValue,
+		Paths:    []string{fieldPath},
+		JSONPath: "$." + fieldPath,
+	}
+}
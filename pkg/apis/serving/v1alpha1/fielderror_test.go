@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFieldErrorsAsError(t *testing.T) {
+	if err := FieldErrors(nil).AsError(); err != nil {
+		t.Errorf("AsError() = %v, want nil for empty FieldErrors", err)
+	}
+
+	errs := FieldErrors{errMissingField("foo")}
+	err := errs.AsError()
+	if err == nil {
+		t.Fatal("AsError() = nil, want an error for non-empty FieldErrors")
+	}
+	if got, want := err.Error(), errs.Error(); got != want {
+		t.Errorf("AsError().Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldErrorsToAdmissionResponse(t *testing.T) {
+	if got := FieldErrors(nil).ToAdmissionResponse(); !got.Allowed {
+		t.Errorf("ToAdmissionResponse() = %+v, want Allowed=true for empty FieldErrors", got)
+	}
+
+	errs := FieldErrors{errMissingField("foo")}
+	got := errs.ToAdmissionResponse()
+	if got.Allowed {
+		t.Errorf("ToAdmissionResponse().Allowed = true, want false for non-empty FieldErrors")
+	}
+	if got.Result == nil {
+		t.Fatal("ToAdmissionResponse().Result = nil, want a populated Status")
+	}
+	if got.Result.Reason != metav1.StatusReasonInvalid {
+		t.Errorf("ToAdmissionResponse().Result.Reason = %q, want %q", got.Result.Reason, metav1.StatusReasonInvalid)
+	}
+	if want := errs.Error(); got.Result.Message != want {
+		t.Errorf("ToAdmissionResponse().Result.Message = %q, want %q", got.Result.Message, want)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint exposes the same Route validation the admission webhook
+// runs, through a stable, offline entrypoint that operates on raw YAML or
+// JSON manifests instead of in-memory API objects. It backs the
+// cmd/serving-lint binary, but is a separate package so it can also be
+// called directly out of CI tooling.
+package lint
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/knative/serving/pkg/apis/serving/v1alpha1"
+)
+
+// CodeRoundTripMismatch is the Finding.Code reported when a document fails
+// CheckRoundTrip, i.e. a key was reordered, renamed, or dropped somewhere
+// between parsing and re-emitting it.
+const CodeRoundTripMismatch = "RoundTripMismatch"
+
+// Finding is a single validation failure located within a parsed manifest
+// stream, carrying enough position information for an editor or CI
+// annotation to point a user at the offending document.
+type Finding struct {
+	v1alpha1.FieldError
+
+	// File is the name of the manifest the finding came from, as passed
+	// to Lint.
+	File string
+	// Line is the line, within File, at which the offending YAML/JSON
+	// document begins. Findings are not currently tracked at per-field
+	// precision; Line locates the document, not the JSONPath within it.
+	Line int
+	// Policy is the EnforcementPolicy this finding was evaluated under
+	// (the Route's serving.knative.dev/enforcementAction annotation, or
+	// the default deny/webhook policy), so a caller can tell a hard
+	// failure from a warning or dry-run-only finding without re-deriving
+	// it from the manifest.
+	Policy v1alpha1.EnforcementPolicy
+}
+
+// Blocks reports whether this finding should be treated as a hard failure
+// under the scoped enforcement rules introduced alongside the webhook
+// (serving.knative.dev/enforcementAction), i.e. whether the same manifest
+// would be rejected by the admission webhook.
+func (f Finding) Blocks() bool {
+	return f.Policy.Action == v1alpha1.EnforceDeny && f.Policy.Scope == v1alpha1.ScopeWebhook
+}
+
+// Lint reads file, a (potentially multi-document, e.g. kustomize-rendered)
+// YAML or JSON stream of Route manifests, and validates each one with the
+// same Validate() path the admission webhook uses, so a manifest that
+// passes serving-lint cannot later be rejected by the webhook for a reason
+// lint didn't already catch.
+func Lint(file string, r io.Reader) ([]Finding, error) {
+	var findings []Finding
+
+	dec := yaml.NewDecoder(r)
+	for docIndex := 0; ; docIndex++ {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("%s: document %d: %w", file, docIndex, err)
+		}
+		if isEmptyDocument(&doc) {
+			continue
+		}
+
+		// v1alpha1 types, like the rest of the Kubernetes API machinery,
+		// only carry `json:` tags. Decoding straight into them with
+		// yaml.v3 would look up lowercased field names (e.g.
+		// "revisionname") that never match camelCase YAML keys, so we
+		// round-trip this document through sigs.k8s.io/yaml, which
+		// converts YAML to JSON before unmarshaling.
+		docBytes, err := yaml.Marshal(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", file, docIndex, err)
+		}
+
+		var route v1alpha1.Route
+		if err := k8syaml.Unmarshal(docBytes, &route); err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", file, docIndex, err)
+		}
+
+		errs := route.Validate()
+		// EnforceValidation evaluates the whole batch against the Route's
+		// enforcementAction annotation at once, since the policy is a
+		// property of the resource, not of any one error.
+		result := v1alpha1.EnforceValidation(&route, errs)
+		for _, fe := range errs {
+			findings = append(findings, Finding{
+				FieldError: *fe,
+				File:       file,
+				Line:       documentLine(&doc),
+				Policy:     result.Policy,
+			})
+		}
+
+		// A round-trip mismatch means the document itself can't be
+		// trusted to survive being read and re-written by tooling, which
+		// isn't a property the enforcementAction annotation opts out of,
+		// so it's always reported as blocking.
+		if ok, diff, err := checkRoundTripNode(&doc); err != nil {
+			return nil, fmt.Errorf("%s: document %d: checking round-trip: %w", file, docIndex, err)
+		} else if !ok {
+			findings = append(findings, Finding{
+				FieldError: v1alpha1.FieldError{
+					Message:    "document does not round-trip through parse/re-emit unchanged",
+					Code:       CodeRoundTripMismatch,
+					Suggestion: diff,
+				},
+				File:   file,
+				Line:   documentLine(&doc),
+				Policy: v1alpha1.EnforcementPolicy{Action: v1alpha1.EnforceDeny, Scope: v1alpha1.ScopeWebhook},
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func isEmptyDocument(n *yaml.Node) bool {
+	return n.Kind == 0 || (n.Kind == yaml.DocumentNode && len(n.Content) == 0)
+}
+
+func documentLine(n *yaml.Node) int {
+	if len(n.Content) > 0 {
+		return n.Content[0].Line
+	}
+	return n.Line
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/knative/serving/pkg/apis/serving/v1alpha1"
+)
+
+const validRouteYAML = `apiVersion: serving.knative.dev/v1alpha1
+kind: Route
+metadata:
+  name: myapp
+spec:
+  traffic:
+  - revisionName: myapp-00001
+    percent: 100
+`
+
+const invalidRouteYAML = `apiVersion: serving.knative.dev/v1alpha1
+kind: Route
+metadata:
+  name: myapp
+spec:
+  traffic:
+  - revisionName: myapp-00001
+    percent: 150
+`
+
+const multiDocYAML = invalidRouteYAML + "---\n" + validRouteYAML
+
+const warnAnnotatedInvalidRouteYAML = `apiVersion: serving.knative.dev/v1alpha1
+kind: Route
+metadata:
+  name: myapp
+  annotations:
+    serving.knative.dev/enforcementAction: warn
+spec:
+  traffic:
+  - revisionName: myapp-00001
+    percent: 150
+`
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantCode    string
+		wantProblem bool
+	}{{
+		name: "valid route",
+		in:   validRouteYAML,
+	}, {
+		name:        "invalid percent",
+		in:          invalidRouteYAML,
+		wantProblem: true,
+		wantCode:    v1alpha1.CodeTrafficPercentOutOfRange,
+	}, {
+		name:        "multi-document stream only flags the bad one",
+		in:          multiDocYAML,
+		wantProblem: true,
+		wantCode:    v1alpha1.CodeTrafficPercentOutOfRange,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := Lint("route.yaml", strings.NewReader(test.in))
+			if err != nil {
+				t.Fatalf("Lint() returned error: %v", err)
+			}
+			if got := len(findings) > 0; got != test.wantProblem {
+				t.Fatalf("got %d findings, want problem=%v: %+v", len(findings), test.wantProblem, findings)
+			}
+			if test.wantProblem && findings[0].Code != test.wantCode {
+				t.Errorf("findings[0].Code = %q, want %q", findings[0].Code, test.wantCode)
+			}
+		})
+	}
+}
+
+func TestLintEnforcementPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         string
+		wantBlocks bool
+	}{{
+		name:       "no annotation defaults to deny, blocks",
+		in:         invalidRouteYAML,
+		wantBlocks: true,
+	}, {
+		name:       "warn annotation does not block",
+		in:         warnAnnotatedInvalidRouteYAML,
+		wantBlocks: false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := Lint("route.yaml", strings.NewReader(test.in))
+			if err != nil {
+				t.Fatalf("Lint() returned error: %v", err)
+			}
+			if len(findings) == 0 {
+				t.Fatal("Lint() = no findings, want at least one")
+			}
+			if got := findings[0].Blocks(); got != test.wantBlocks {
+				t.Errorf("findings[0].Blocks() = %v, want %v", got, test.wantBlocks)
+			}
+		})
+	}
+}
+
+func TestLintChecksRoundTrip(t *testing.T) {
+	for _, in := range []string{validRouteYAML, invalidRouteYAML} {
+		findings, err := Lint("route.yaml", strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("Lint() returned error: %v", err)
+		}
+		for _, f := range findings {
+			if f.Code == CodeRoundTripMismatch {
+				t.Errorf("Lint() reported a round-trip mismatch on well-formed input: %+v", f)
+			}
+		}
+	}
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	ok, diff, err := CheckRoundTrip([]byte(validRouteYAML))
+	if err != nil {
+		t.Fatalf("CheckRoundTrip() returned error: %v", err)
+	}
+	if !ok {
+		t.Errorf("CheckRoundTrip() = false, want true; diff:\n%s", diff)
+	}
+}
+
+func TestCheckRoundTripMalformed(t *testing.T) {
+	if _, _, err := CheckRoundTrip([]byte("not: [valid")); err == nil {
+		t.Error("CheckRoundTrip() = nil error, want a parse error for malformed YAML")
+	}
+}
+
+func TestDiffStructureDetectsReorderedKeys(t *testing.T) {
+	a := mappingNode("name", scalarNode("foo"), "percent", scalarNode("100"))
+	b := mappingNode("percent", scalarNode("100"), "name", scalarNode("foo"))
+
+	if d := diffStructure(a, b, "$"); d == "" {
+		t.Error("diffStructure() = \"\", want a diff for reordered keys")
+	}
+}
+
+func TestDiffStructureDetectsDroppedKey(t *testing.T) {
+	a := mappingNode("name", scalarNode("foo"), "percent", scalarNode("100"))
+	b := mappingNode("name", scalarNode("foo"))
+
+	if d := diffStructure(a, b, "$"); d == "" {
+		t.Error("diffStructure() = \"\", want a diff for a dropped key")
+	}
+}
+
+func mappingNode(kv ...interface{}) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < len(kv); i += 2 {
+		n.Content = append(n.Content, scalarNode(kv[i].(string)), kv[i+1].(*yaml.Node))
+	}
+	return n
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Report writes findings to w in the requested format.
+func Report(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case "", "text":
+		for _, f := range findings {
+			fmt.Fprintf(w, "%s:%d: [%s/%s] %s\n", f.File, f.Line, f.Policy.Action, f.Code, f.Error())
+		}
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	case "sarif":
+		return writeSARIF(w, findings)
+	default:
+		return fmt.Errorf("unknown --format %q, want text, json, or sarif", format)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckRoundTrip verifies that parsing raw and re-emitting it preserves
+// every key (including annotations) and its ordering, without requiring
+// the re-emitted YAML to be byte-identical to the input. Asserting on the
+// encoder's own indentation and block/flow style choices would make this
+// check fail on any normally-formatted manifest whose style happens to
+// differ from yaml.v3's defaults; what actually matters for downstream
+// tooling is that nothing was reordered, renamed, or dropped.
+func CheckRoundTrip(raw []byte) (ok bool, diff string, err error) {
+	var original yaml.Node
+	if err := yaml.Unmarshal(raw, &original); err != nil {
+		return false, "", fmt.Errorf("parsing: %w", err)
+	}
+	return checkRoundTripNode(&original)
+}
+
+// checkRoundTripNode is the node-based core of CheckRoundTrip, shared with
+// Lint, which already holds a parsed yaml.Node per document and would
+// otherwise have to re-parse raw bytes it has already consumed from the
+// stream.
+func checkRoundTripNode(original *yaml.Node) (ok bool, diff string, err error) {
+	out, err := yaml.Marshal(original)
+	if err != nil {
+		return false, "", fmt.Errorf("re-emitting: %w", err)
+	}
+
+	var reemitted yaml.Node
+	if err := yaml.Unmarshal(out, &reemitted); err != nil {
+		return false, "", fmt.Errorf("re-parsing re-emitted document: %w", err)
+	}
+
+	if d := diffStructure(original, &reemitted, "$"); d != "" {
+		return false, d, nil
+	}
+	return true, "", nil
+}
+
+// diffStructure compares two parsed YAML documents for structural
+// equality (key presence, key order, and scalar values), ignoring
+// formatting details like indentation, quoting, and block/flow style.
+// It returns a human-readable description of the first difference found,
+// or "" if the documents are structurally equivalent.
+func diffStructure(a, b *yaml.Node, path string) string {
+	a, b = unwrapDocument(a), unwrapDocument(b)
+
+	if a.Kind != b.Kind {
+		return fmt.Sprintf("%s: node kind changed", path)
+	}
+
+	switch a.Kind {
+	case yaml.ScalarNode:
+		if a.Value != b.Value {
+			return fmt.Sprintf("%s: value changed from %q to %q", path, a.Value, b.Value)
+		}
+
+	case yaml.MappingNode:
+		if len(a.Content) != len(b.Content) {
+			return fmt.Sprintf("%s: key count changed from %d to %d", path, len(a.Content)/2, len(b.Content)/2)
+		}
+		for i := 0; i < len(a.Content); i += 2 {
+			aKey, aVal := a.Content[i], a.Content[i+1]
+			bKey, bVal := b.Content[i], b.Content[i+1]
+			if aKey.Value != bKey.Value {
+				return fmt.Sprintf("%s: key order changed, expected %q got %q", path, aKey.Value, bKey.Value)
+			}
+			if d := diffStructure(aVal, bVal, path+"."+aKey.Value); d != "" {
+				return d
+			}
+		}
+
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			return fmt.Sprintf("%s: sequence length changed from %d to %d", path, len(a.Content), len(b.Content))
+		}
+		for i := range a.Content {
+			if d := diffStructure(a.Content[i], b.Content[i], fmt.Sprintf("%s[%d]", path, i)); d != "" {
+				return d
+			}
+		}
+	}
+
+	return ""
+}
+
+func unwrapDocument(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		return n.Content[0]
+	}
+	return n
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "fmt"
+
+// TagHostname computes the hostname that the Route reconciler should
+// provision for a tagged TrafficTarget, given the Route's default domain
+// (e.g. "myapp.default.example.com"). The tagged hostname prefixes the
+// first label of the domain so that "stable" against "myapp.default.example.com"
+// yields "stable-myapp.default.example.com", letting clients address a
+// specific revision/tag directly without going through the percentage split.
+func TagHostname(tag, domain string) string {
+	if tag == "" {
+		return domain
+	}
+	return fmt.Sprintf("%s-%s", tag, domain)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Route is responsible for configuring ingress over a collection of Revisions
+// and how much of the traffic is routed to each one.
+type Route struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteSpec   `json:"spec,omitempty"`
+	Status RouteStatus `json:"status,omitempty"`
+}
+
+// RouteSpec holds the desired state of the Route (from the client).
+type RouteSpec struct {
+	// Traffic specifies how to distribute traffic over a collection of
+	// Revisions and Configurations.
+	// +optional
+	Traffic []TrafficTarget `json:"traffic,omitempty"`
+}
+
+// TrafficTarget holds a single entry of the routing table for a Route,
+// describing what percentage of traffic is routed to a Revision, either
+// directly by name, or indirectly through the latest ready Revision of a
+// Configuration.
+type TrafficTarget struct {
+	// Name is optionally used to expose a dedicated subroute name for
+	// referencing this target exclusively. It has the form
+	// {Name}.{Route.Name}.{Route.Namespace}.{ClusterDomain}.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Tag is optionally used to expose a dedicated hostname for referencing
+	// this target exclusively, independent of Name. Unlike Name, Tag is not
+	// interpreted as a subroute and is meant to back tooling such as
+	// `kn service update --tag`. Tags must be unique across the Traffic
+	// list and are valid DNS labels.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// RevisionName of a specific revision to which to send this portion of
+	// traffic. This is mutually exclusive with ConfigurationName.
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// ConfigurationName of a configuration to whose latest ready revision we
+	// will send this portion of traffic. This is mutually exclusive with
+	// RevisionName.
+	// +optional
+	ConfigurationName string `json:"configurationName,omitempty"`
+
+	// LatestRevision may be optionally provided to indicate that the
+	// latest ready Revision of the Configuration should be used for this
+	// traffic target. It may only be set to true when RevisionName is
+	// empty; false or unset is accepted alongside either ConfigurationName
+	// or RevisionName. When true, this is mutually exclusive with
+	// RevisionName.
+	// +optional
+	LatestRevision *bool `json:"latestRevision,omitempty"`
+
+	// Percent indicates that percentage based routing should be used and
+	// the value indicates the percent of traffic that is be routed to this
+	// Revision or Configuration. A Tag-only target (no traffic share) may
+	// set Percent to 0.
+	// +optional
+	Percent int `json:"percent,omitempty"`
+}
+
+// RouteStatus communicates the observed state of the Route (from the
+// controller).
+type RouteStatus struct {
+	// Domain holds the top-level domain that will distribute traffic over
+	// the provided targets.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// Traffic holds the configuration we last believe to have been
+	// successfully applied.
+	// +optional
+	Traffic []TrafficTarget `json:"traffic,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RouteList is a list of Route resources.
+type RouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Route `json:"items"`
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// tagLabelRegexp matches a valid DNS-1035 label, which is what Tag gets
+// prepended to a Route's domain as, e.g. "stable-myapp.default.example.com".
+var tagLabelRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+const maxTagLength = 63
+
+// Validate makes sure that Route is properly configured.
+func (r *Route) Validate() FieldErrors {
+	return r.Spec.Validate().ViaField("spec")
+}
+
+// Validate makes sure that RouteSpec is properly configured. Unlike a
+// short-circuiting validator, it accumulates every problem it finds (e.g.
+// a bad percent on one target and a name collision between two others)
+// into a single FieldErrors instead of returning on the first one.
+func (rs *RouteSpec) Validate() FieldErrors {
+	var errs FieldErrors
+
+	if len(rs.Traffic) == 0 {
+		return errs.Also(errMissingField(currentField))
+	}
+
+	percentSum := 0
+	seenNames := map[string]int{} // name -> index of first target with that name.
+	seenTags := map[string]int{}  // tag -> index of first target with that tag.
+	for i, tt := range rs.Traffic {
+		errs = errs.Also(tt.Validate().ViaField(fmt.Sprintf("traffic[%d]", i))...)
+
+		if tt.Name != "" {
+			if j, ok := seenNames[tt.Name]; ok {
+				prev := rs.Traffic[j]
+				if prev.RevisionName != tt.RevisionName || prev.ConfigurationName != tt.ConfigurationName {
+					errs = errs.Also(&FieldError{
+						Message: fmt.Sprintf("Multiple definitions for %q", tt.Name),
+						Code:    CodeDuplicateTrafficName,
+						Paths:   []string{fmt.Sprintf("traffic[%d].name", j), fmt.Sprintf("traffic[%d].name", i)},
+					})
+				}
+			} else {
+				seenNames[tt.Name] = i
+			}
+		}
+
+		if tt.Tag != "" {
+			if j, ok := seenTags[tt.Tag]; ok {
+				errs = errs.Also(&FieldError{
+					Message: fmt.Sprintf("Multiple definitions for tag %q", tt.Tag),
+					Code:    CodeDuplicateTrafficTag,
+					Paths:   []string{fmt.Sprintf("traffic[%d].tag", j), fmt.Sprintf("traffic[%d].tag", i)},
+				})
+			} else {
+				seenTags[tt.Tag] = i
+			}
+		}
+
+		percentSum += tt.Percent
+	}
+
+	if percentSum != 100 {
+		errs = errs.Also(&FieldError{
+			Message:  fmt.Sprintf("Traffic targets sum to %d, want 100", percentSum),
+			Code:     CodeTrafficPercentOutOfRange,
+			Paths:    []string{"traffic"},
+			JSONPath: "$.traffic",
+		})
+	}
+
+	return errs
+}
+
+// Validate makes sure that TrafficTarget is properly configured.
+func (tt *TrafficTarget) Validate() FieldErrors {
+	var errs FieldErrors
+
+	switch {
+	case tt.RevisionName != "" && tt.ConfigurationName != "":
+		errs = errs.Also(&FieldError{
+			Message: "Expected exactly one, got both",
+			Code:    CodeTrafficTargetAmbiguous,
+			Paths:   []string{"revisionName", "configurationName"},
+		})
+	case tt.RevisionName == "" && tt.ConfigurationName == "":
+		errs = errs.Also(&FieldError{
+			Message: "Expected exactly one, got neither",
+			Code:    CodeTrafficTargetAmbiguous,
+			Paths:   []string{"revisionName", "configurationName"},
+		})
+	}
+
+	if tt.LatestRevision != nil && *tt.LatestRevision && tt.RevisionName != "" {
+		errs = errs.Also(&FieldError{
+			Message: "LatestRevision may not be set with revisionName",
+			Code:    CodeTrafficTargetAmbiguous,
+			Paths:   []string{"revisionName", "latestRevision"},
+		})
+	}
+
+	if tt.Percent < 0 || tt.Percent > 100 {
+		errs = errs.Also(&FieldError{
+			Message:    fmt.Sprintf("invalid value %q", fmt.Sprint(tt.Percent)),
+			Code:       CodeTrafficPercentOutOfRange,
+			Paths:      []string{"percent"},
+			JSONPath:   "$.percent",
+			Suggestion: "percent must be between 0 and 100",
+		})
+	}
+
+	if tt.Tag != "" && (len(tt.Tag) > maxTagLength || !tagLabelRegexp.MatchString(tt.Tag)) {
+		errs = errs.Also(errInvalidValue(tt.Tag, "tag"))
+	}
+
+	return errs
+}
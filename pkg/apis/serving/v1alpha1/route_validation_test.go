@@ -26,7 +26,7 @@ func TestRouteValidation(t *testing.T) {
 	tests := []struct {
 		name string
 		r    *Route
-		want *FieldError
+		want FieldErrors
 	}{{
 		name: "valid",
 		r: &Route{
@@ -64,19 +64,20 @@ func TestRouteValidation(t *testing.T) {
 				}},
 			},
 		},
-		want: &FieldError{
+		want: FieldErrors{{
 			Message: "Expected exactly one, got neither",
+			Code:    CodeTrafficTargetAmbiguous,
 			Paths: []string{
 				"spec.traffic[0].revisionName",
 				"spec.traffic[0].configurationName",
 			},
-		},
+		}},
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			got := test.r.Validate()
-			if diff := cmp.Diff(test.want, got); diff != "" {
+			if diff := cmp.Diff(test.want, got, FieldErrorsCompareOption); diff != "" {
 				t.Errorf("Validate (-want, +got) = %v", diff)
 			}
 		})
@@ -87,7 +88,7 @@ func TestRouteSpecValidation(t *testing.T) {
 	tests := []struct {
 		name string
 		rs   *RouteSpec
-		want *FieldError
+		want FieldErrors
 	}{{
 		name: "valid",
 		rs: &RouteSpec{
@@ -114,7 +115,7 @@ func TestRouteSpecValidation(t *testing.T) {
 	}, {
 		name: "empty spec",
 		rs:   &RouteSpec{},
-		want: errMissingField(currentField),
+		want: FieldErrors{errMissingField(currentField)},
 	}, {
 		name: "invalid traffic entry",
 		rs: &RouteSpec{
@@ -123,10 +124,11 @@ func TestRouteSpecValidation(t *testing.T) {
 				Percent: 100,
 			}},
 		},
-		want: &FieldError{
+		want: FieldErrors{{
 			Message: "Expected exactly one, got neither",
+			Code:    CodeTrafficTargetAmbiguous,
 			Paths:   []string{"traffic[0].revisionName", "traffic[0].configurationName"},
-		},
+		}},
 	}, {
 		name: "invalid name conflict",
 		rs: &RouteSpec{
@@ -140,10 +142,11 @@ func TestRouteSpecValidation(t *testing.T) {
 				Percent:      50,
 			}},
 		},
-		want: &FieldError{
+		want: FieldErrors{{
 			Message: `Multiple definitions for "foo"`,
+			Code:    CodeDuplicateTrafficName,
 			Paths:   []string{"traffic[0].name", "traffic[1].name"},
-		},
+		}},
 	}, {
 		name: "valid name collision (same revision)",
 		rs: &RouteSpec{
@@ -169,27 +172,65 @@ func TestRouteSpecValidation(t *testing.T) {
 				Percent:      99,
 			}},
 		},
-		want: &FieldError{
-			Message: "Traffic targets sum to 198, want 100",
-			Paths:   []string{"traffic"},
-		},
+		want: FieldErrors{{
+			Message:  "Traffic targets sum to 198, want 100",
+			Code:     CodeTrafficPercentOutOfRange,
+			Paths:    []string{"traffic"},
+			JSONPath: "$.traffic",
+		}},
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			got := test.rs.Validate()
-			if diff := cmp.Diff(test.want, got); diff != "" {
+			if diff := cmp.Diff(test.want, got, FieldErrorsCompareOption); diff != "" {
 				t.Errorf("Validate (-want, +got) = %v", diff)
 			}
 		})
 	}
 }
 
+// TestRouteSpecValidationMultipleErrors covers the FieldErrors accumulation
+// behavior: a spec with independent problems (an out-of-range percent on
+// one target, a name collision between two others) surfaces all of them
+// from a single Validate() call instead of only the first one found.
+func TestRouteSpecValidationMultipleErrors(t *testing.T) {
+	rs := &RouteSpec{
+		Traffic: []TrafficTarget{{
+			Name:         "foo",
+			RevisionName: "bar",
+			Percent:      150,
+		}, {
+			Name:         "foo",
+			RevisionName: "baz",
+			Percent:      50,
+		}},
+	}
+
+	got := rs.Validate()
+
+	var sawPercentError, sawDuplicateNameError bool
+	for _, err := range got {
+		switch err.Code {
+		case CodeTrafficPercentOutOfRange:
+			sawPercentError = true
+		case CodeDuplicateTrafficName:
+			sawDuplicateNameError = true
+		}
+	}
+	if !sawPercentError {
+		t.Errorf("Validate() = %v, want it to include a %s error", got, CodeTrafficPercentOutOfRange)
+	}
+	if !sawDuplicateNameError {
+		t.Errorf("Validate() = %v, want it to include a %s error", got, CodeDuplicateTrafficName)
+	}
+}
+
 func TestTrafficTargetValidation(t *testing.T) {
 	tests := []struct {
 		name string
 		tt   *TrafficTarget
-		want *FieldError
+		want FieldErrors
 	}{{
 		name: "valid with name and revision",
 		tt: &TrafficTarget{
@@ -226,42 +267,142 @@ func TestTrafficTargetValidation(t *testing.T) {
 			RevisionName:      "foo",
 			ConfigurationName: "bar",
 		},
-		want: &FieldError{
+		want: FieldErrors{{
 			Message: "Expected exactly one, got both",
+			Code:    CodeTrafficTargetAmbiguous,
 			Paths:   []string{"revisionName", "configurationName"},
-		},
+		}},
 	}, {
 		name: "invalid with neither",
 		tt: &TrafficTarget{
 			Name:    "foo",
 			Percent: 100,
 		},
-		want: &FieldError{
+		want: FieldErrors{{
 			Message: "Expected exactly one, got neither",
+			Code:    CodeTrafficTargetAmbiguous,
 			Paths:   []string{"revisionName", "configurationName"},
-		},
+		}},
 	}, {
 		name: "invalid percent too low",
 		tt: &TrafficTarget{
 			RevisionName: "foo",
 			Percent:      -5,
 		},
-		want: errInvalidValue("-5", "percent"),
+		want: FieldErrors{{
+			Message:    `invalid value "-5"`,
+			Code:       CodeTrafficPercentOutOfRange,
+			Paths:      []string{"percent"},
+			JSONPath:   "$.percent",
+			Suggestion: "percent must be between 0 and 100",
+		}},
 	}, {
 		name: "invalid percent too high",
 		tt: &TrafficTarget{
 			RevisionName: "foo",
 			Percent:      101,
 		},
-		want: errInvalidValue("101", "percent"),
+		want: FieldErrors{{
+			Message:    `invalid value "101"`,
+			Code:       CodeTrafficPercentOutOfRange,
+			Paths:      []string{"percent"},
+			JSONPath:   "$.percent",
+			Suggestion: "percent must be between 0 and 100",
+		}},
+	}, {
+		name: "valid tag-only target with zero percent",
+		tt: &TrafficTarget{
+			Tag:               "stable",
+			ConfigurationName: "bar",
+		},
+		want: nil,
+	}, {
+		name: "valid with latestRevision true and configurationName",
+		tt: &TrafficTarget{
+			ConfigurationName: "bar",
+			LatestRevision:    boolPtr(true),
+			Percent:           100,
+		},
+		want: nil,
+	}, {
+		name: "invalid latestRevision true with revisionName",
+		tt: &TrafficTarget{
+			RevisionName:   "foo",
+			LatestRevision: boolPtr(true),
+		},
+		want: FieldErrors{{
+			Message: "LatestRevision may not be set with revisionName",
+			Code:    CodeTrafficTargetAmbiguous,
+			Paths:   []string{"revisionName", "latestRevision"},
+		}},
+	}, {
+		name: "invalid tag not a DNS label",
+		tt: &TrafficTarget{
+			Tag:               "Not_A_Label",
+			ConfigurationName: "bar",
+		},
+		want: FieldErrors{errInvalidValue("Not_A_Label", "tag")},
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			got := test.tt.Validate()
-			if diff := cmp.Diff(test.want, got); diff != "" {
+			if diff := cmp.Diff(test.want, got, FieldErrorsCompareOption); diff != "" {
 				t.Errorf("Validate (-want, +got) = %v", diff)
 			}
 		})
 	}
 }
+
+func TestRouteSpecValidationDuplicateTag(t *testing.T) {
+	rs := &RouteSpec{
+		Traffic: []TrafficTarget{{
+			Tag:          "stable",
+			RevisionName: "bar",
+			Percent:      50,
+		}, {
+			Tag:          "stable",
+			RevisionName: "baz",
+			Percent:      50,
+		}},
+	}
+	want := FieldErrors{{
+		Message: `Multiple definitions for tag "stable"`,
+		Code:    CodeDuplicateTrafficTag,
+		Paths:   []string{"traffic[0].tag", "traffic[1].tag"},
+	}}
+	if diff := cmp.Diff(want, rs.Validate(), FieldErrorsCompareOption); diff != "" {
+		t.Errorf("Validate (-want, +got) = %v", diff)
+	}
+}
+
+func TestTagHostname(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		domain string
+		want   string
+	}{{
+		name:   "no tag",
+		tag:    "",
+		domain: "myapp.default.example.com",
+		want:   "myapp.default.example.com",
+	}, {
+		name:   "tagged",
+		tag:    "stable",
+		domain: "myapp.default.example.com",
+		want:   "stable-myapp.default.example.com",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := TagHostname(test.tag, test.domain); got != test.want {
+				t.Errorf("TagHostname() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}